@@ -1,23 +1,15 @@
-package assets
+//go:build !noassets
 
-import (
-	"log/slog"
-)
+package assets
 
-// 簡化的assets包，移除embed指令避免編譯錯誤
-var (
-	NodeBinary     []byte
-	ACL4SSRConfig  []byte
-	SubStoreBundle []byte
-)
+import _ "embed"
 
-// RunSubStoreService 運行SubStore服務（簡化版本）
-func RunSubStoreService() {
-	slog.Info("SubStore服務已啟動（簡化版本）")
-	// 這裡可以添加實際的SubStore服務邏輯
-}
+// SubStoreBundle 是打包好的 SubStore JS bundle
+//
+//go:embed data/substore.bundle.js
+var SubStoreBundle []byte
 
-// 初始化資源
-func init() {
-	// 資源初始化邏輯
-}
+// ACL4SSRConfig 是 SubStore 依赖的 ACL4SSR 规则配置
+//
+//go:embed data/acl4ssr.yaml
+var ACL4SSRConfig []byte
@@ -0,0 +1,9 @@
+//go:build noassets
+
+package assets
+
+// 精简构建（noassets）不内嵌 SubStore bundle，Start 会在没有资源时直接报错
+var (
+	SubStoreBundle []byte
+	ACL4SSRConfig  []byte
+)
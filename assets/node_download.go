@@ -0,0 +1,187 @@
+package assets
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// nodeDownloadVersion 是找不到本地 node 时回退下载的版本，
+// 对应 https://nodejs.org/dist/ 下的目录
+const nodeDownloadVersion = "v20.11.1"
+
+// downloadNode 从 nodejs.org 下载一份便携版 Node.js，解压出 node 可执行文件
+// 并放入 destDir，返回其路径。目标平台/架构不在预置列表中时直接报错
+func downloadNode(ctx context.Context, destDir string) (string, error) {
+	platform, ok := nodeReleasePlatform()
+	if !ok {
+		return "", fmt.Errorf("不支持为 %s/%s 自动下载 node", runtime.GOOS, runtime.GOARCH)
+	}
+
+	archiveName := fmt.Sprintf("node-%s-%s", nodeDownloadVersion, platform)
+	ext := ".tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = ".zip"
+	}
+	url := fmt.Sprintf("https://nodejs.org/dist/%s/%s%s", nodeDownloadVersion, archiveName, ext)
+
+	slog.Info(fmt.Sprintf("未找到本地 node，正在从 %s 下载便携版本", url))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("构造下载请求失败: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("下载 node 失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("下载 node 返回非成功状态码: %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return "", fmt.Errorf("创建 node 缓存目录失败: %w", err)
+	}
+
+	nodeBinName := "bin/node"
+	if runtime.GOOS == "windows" {
+		nodeBinName = "node.exe"
+	}
+	// 压缩包内统一以 "<archiveName>/" 为根目录
+	wantSuffix := archiveName + "/" + nodeBinName
+
+	var extracted string
+	if runtime.GOOS == "windows" {
+		extracted, err = extractFromZip(resp.Body, wantSuffix, destDir, "node.exe")
+	} else {
+		extracted, err = extractFromTarGz(resp.Body, wantSuffix, destDir, "node")
+	}
+	if err != nil {
+		return "", fmt.Errorf("解压 node 失败: %w", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(extracted, 0o755); err != nil {
+			return "", fmt.Errorf("设置 node 可执行权限失败: %w", err)
+		}
+	}
+	return extracted, nil
+}
+
+// nodeReleasePlatform 将 GOOS/GOARCH 映射为 nodejs.org 发布包使用的平台标识
+func nodeReleasePlatform() (string, bool) {
+	var osName string
+	switch runtime.GOOS {
+	case "linux":
+		osName = "linux"
+	case "darwin":
+		osName = "darwin"
+	case "windows":
+		osName = "win"
+	default:
+		return "", false
+	}
+
+	var archName string
+	switch runtime.GOARCH {
+	case "amd64":
+		archName = "x64"
+	case "arm64":
+		archName = "arm64"
+	default:
+		return "", false
+	}
+
+	return fmt.Sprintf("%s-%s", osName, archName), true
+}
+
+// extractFromTarGz 从 gzip 压缩的 tar 归档中取出名称与 wantSuffix 完全一致的条目，
+// 写入 destDir/outName 并返回其路径
+func extractFromTarGz(r io.Reader, wantSuffix, destDir, outName string) (string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return "", fmt.Errorf("打开 gzip 流失败: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return "", fmt.Errorf("归档中未找到 %s", wantSuffix)
+		}
+		if err != nil {
+			return "", fmt.Errorf("读取 tar 归档失败: %w", err)
+		}
+		if header.Name != wantSuffix {
+			continue
+		}
+
+		outPath := filepath.Join(destDir, outName)
+		out, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+		if err != nil {
+			return "", fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, tr); err != nil {
+			return "", fmt.Errorf("写入输出文件失败: %w", err)
+		}
+		return outPath, nil
+	}
+}
+
+// extractFromZip 从 zip 归档中取出名称与 wantSuffix 完全一致的条目，
+// 写入 destDir/outName 并返回其路径。zip.Reader 需要 ReaderAt，
+// 因此先把响应体缓冲到临时文件
+func extractFromZip(r io.Reader, wantSuffix, destDir, outName string) (string, error) {
+	tmp, err := os.CreateTemp("", "node-download-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return "", fmt.Errorf("缓冲下载内容失败: %w", err)
+	}
+
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return "", fmt.Errorf("打开 zip 归档失败: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if f.Name != wantSuffix {
+			continue
+		}
+		src, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("读取 zip 条目失败: %w", err)
+		}
+		defer src.Close()
+
+		outPath := filepath.Join(destDir, outName)
+		out, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+		if err != nil {
+			return "", fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, src); err != nil {
+			return "", fmt.Errorf("写入输出文件失败: %w", err)
+		}
+		return outPath, nil
+	}
+	return "", fmt.Errorf("归档中未找到 %s", wantSuffix)
+}
@@ -0,0 +1,218 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// bundleVersion 用于派生缓存目录，bundle 内容变化时应当同步更新
+const bundleVersion = "v1"
+
+const (
+	defaultPort        = 3000
+	healthCheckTimeout = 5 * time.Second
+	healthCheckRetries = 10
+	healthCheckDelay   = 300 * time.Millisecond
+)
+
+// ServiceStatus 描述 SubStore 子进程的当前状态
+type ServiceStatus struct {
+	Running bool
+	PID     int
+	Port    int
+}
+
+var (
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	cancel  context.CancelFunc
+	running bool
+	port    int
+)
+
+// Start 提取内嵌的 SubStore bundle 到本地缓存目录，并启动 Node 进程提供服务。
+// port 为 0 时使用默认端口 3000
+func Start(ctx context.Context, listenPort int) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if running {
+		return fmt.Errorf("SubStore 服务已在运行")
+	}
+	if len(SubStoreBundle) == 0 {
+		return fmt.Errorf("当前构建未内嵌 SubStore bundle（noassets 构建）")
+	}
+	if listenPort == 0 {
+		listenPort = defaultPort
+	}
+
+	cacheDir, err := extractBundle()
+	if err != nil {
+		return fmt.Errorf("提取 SubStore 资源失败: %w", err)
+	}
+
+	nodeBin, err := locateNode(ctx)
+	if err != nil {
+		return fmt.Errorf("定位 Node.js 失败: %w", err)
+	}
+
+	runCtx, runCancel := context.WithCancel(ctx)
+	c := exec.CommandContext(runCtx, nodeBin, filepath.Join(cacheDir, "substore.bundle.js"))
+	c.Env = append(os.Environ(), fmt.Sprintf("SUBSTORE_PORT=%d", listenPort))
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	if err := c.Start(); err != nil {
+		runCancel()
+		return fmt.Errorf("启动 SubStore 进程失败: %w", err)
+	}
+
+	cmd = c
+	cancel = runCancel
+	running = true
+	port = listenPort
+
+	slog.Info(fmt.Sprintf("SubStore 服务已启动，端口 %d，PID %d", port, cmd.Process.Pid))
+
+	go func() {
+		err := c.Wait()
+		mu.Lock()
+		running = false
+		mu.Unlock()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("SubStore 进程退出: %v", err))
+		}
+	}()
+
+	if err := waitHealthy(listenPort); err != nil {
+		runCancel()
+		running = false
+		return fmt.Errorf("SubStore 服务未能就绪，已终止: %w", err)
+	}
+	return nil
+}
+
+// Stop 结束正在运行的 SubStore 进程
+func Stop() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !running || cancel == nil {
+		return nil
+	}
+	cancel()
+	running = false
+	slog.Info("SubStore 服务已停止")
+	return nil
+}
+
+// Status 返回 SubStore 子进程的当前运行状态
+func Status() ServiceStatus {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := ServiceStatus{Running: running, Port: port}
+	if running && cmd != nil && cmd.Process != nil {
+		s.PID = cmd.Process.Pid
+	}
+	return s
+}
+
+// extractBundle 将内嵌的 bundle 与配置写入用户配置目录下的版本化缓存目录，
+// 写入前通过 SHA256 比对跳过已是最新内容的情况
+func extractBundle() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户配置目录失败: %w", err)
+	}
+
+	cacheDir := filepath.Join(configDir, "subscheck-singbox", "substore", bundleVersion)
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	files := map[string][]byte{
+		"substore.bundle.js": SubStoreBundle,
+		"acl4ssr.yaml":       ACL4SSRConfig,
+	}
+	for name, content := range files {
+		path := filepath.Join(cacheDir, name)
+		if fileMatchesSHA256(path, content) {
+			continue
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return "", fmt.Errorf("写入 %s 失败: %w", name, err)
+		}
+	}
+	return cacheDir, nil
+}
+
+func fileMatchesSHA256(path string, want []byte) bool {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	a := sha256.Sum256(existing)
+	b := sha256.Sum256(want)
+	return hex.EncodeToString(a[:]) == hex.EncodeToString(b[:])
+}
+
+// locateNode 在 PATH 中查找 node，找不到时尝试下载一份便携版本到缓存目录
+func locateNode(ctx context.Context) (string, error) {
+	if bin, err := exec.LookPath("node"); err == nil {
+		return bin, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户配置目录失败: %w", err)
+	}
+	nodeDir := filepath.Join(configDir, "subscheck-singbox", "node")
+
+	nodeBinName := "node"
+	if runtime.GOOS == "windows" {
+		nodeBinName = "node.exe"
+	}
+	cachedBin := filepath.Join(nodeDir, nodeBinName)
+	if _, err := os.Stat(cachedBin); err == nil {
+		return cachedBin, nil
+	}
+
+	bin, err := downloadNode(ctx, nodeDir)
+	if err != nil {
+		return "", fmt.Errorf("自动下载 node 失败，请安装 Node.js 或将其加入 PATH: %w", err)
+	}
+	return bin, nil
+}
+
+// waitHealthy 轮询 /health 接口，直到服务就绪或重试耗尽
+func waitHealthy(listenPort int) error {
+	url := fmt.Sprintf("http://127.0.0.1:%d/health", listenPort)
+	client := &http.Client{Timeout: healthCheckTimeout}
+
+	var lastErr error
+	for i := 0; i < healthCheckRetries; i++ {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			lastErr = fmt.Errorf("健康检查返回状态码 %d", resp.StatusCode)
+		} else {
+			lastErr = err
+		}
+		time.Sleep(healthCheckDelay)
+	}
+	return fmt.Errorf("SubStore 服务健康检查未通过: %w", lastErr)
+}
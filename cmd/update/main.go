@@ -0,0 +1,42 @@
+// Command update 检查并安装 subscheck-singbox 的新版本
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"subscheck-singbox/config"
+	"subscheck-singbox/utils/selfupdate"
+)
+
+func main() {
+	checkOnly := flag.Bool("check-only", false, "仅检查是否有新版本可用，不执行更新")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := config.GlobalConfig.SelfUpdate
+
+	release, newer, err := selfupdate.Check(ctx, cfg.ReleasesURL, cfg.CurrentVersion)
+	if err != nil {
+		slog.Error(fmt.Sprintf("检查更新失败: %v", err))
+		os.Exit(1)
+	}
+
+	if !newer {
+		fmt.Printf("当前已是最新版本: %s\n", cfg.CurrentVersion)
+		return
+	}
+
+	fmt.Printf("发现新版本: %s (当前版本: %s)\n", release.Version(), cfg.CurrentVersion)
+	if *checkOnly {
+		return
+	}
+
+	if err := selfupdate.Apply(ctx, release); err != nil {
+		slog.Error(fmt.Sprintf("更新失败: %v", err))
+		os.Exit(1)
+	}
+}
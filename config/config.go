@@ -0,0 +1,87 @@
+package config
+
+import "time"
+
+// GlobalConfig 保存程序运行期间的全局配置
+var GlobalConfig Config
+
+// Config 描述程序的全局配置项
+type Config struct {
+	// CallbackTargets 是检测完成后触发的回调目标列表，
+	// 取代了旧版单一的 CallbackScript 字段
+	CallbackTargets []CallbackTarget
+
+	// SelfUpdate 配置自更新功能使用的发布源与当前版本号
+	SelfUpdate SelfUpdateConfig
+}
+
+// SelfUpdateConfig 描述自更新功能的配置
+type SelfUpdateConfig struct {
+	// ReleasesURL 指向 GitHub Releases API 的 "latest" 端点
+	ReleasesURL string
+	// CurrentVersion 是当前运行二进制的语义化版本号
+	CurrentVersion string
+}
+
+// CallbackTarget 描述一个回调目标的配置
+//
+// Type 决定使用哪种分发器（exec/http/telegram/discord/selfupdate），
+// 对应类型的配置字段会被填充，其余字段保持零值
+//
+// mqtt 目标暂缓加入：它依赖的 github.com/eclipse/paho.mqtt.golang
+// 尚未在 go.mod/go.sum 中落地，等依赖接入后再恢复
+type CallbackTarget struct {
+	Type       string
+	Timeout    time.Duration
+	MaxRetries int
+
+	Exec       *ExecTargetConfig
+	HTTP       *HTTPTargetConfig
+	Telegram   *TelegramTargetConfig
+	Discord    *DiscordTargetConfig
+	SelfUpdate *SelfUpdateTargetConfig
+}
+
+// SelfUpdateTargetConfig 描述一个以触发自更新为动作的回调目标
+type SelfUpdateTargetConfig struct {
+	// MinSuccessRate 低于该成功率（0-1）时才会触发更新检查，置 0 表示每次都检查
+	MinSuccessRate float64
+}
+
+// ExecTargetConfig 对应旧版的脚本回调方式
+type ExecTargetConfig struct {
+	ScriptPath string
+	// Sandbox 为空时不降权、不施加资源限制；在 Linux 上子进程仍运行于独立进程组中，
+	// 超时时整组被杀死
+	Sandbox *SandboxConfig
+}
+
+// SandboxConfig 描述执行回调脚本时对子进程施加的隔离与资源限制
+type SandboxConfig struct {
+	// RunAsUID/RunAsGID 非 nil 时在 Linux 上将子进程的用户/组降权至该 ID
+	RunAsUID *uint32
+	RunAsGID *uint32
+
+	// MaxCPUSeconds/MaxMemoryBytes/MaxFileSizeBytes 为 0 表示不限制
+	MaxCPUSeconds    uint64
+	MaxMemoryBytes   uint64
+	MaxFileSizeBytes uint64
+}
+
+// HTTPTargetConfig 描述 HTTP Webhook 回调目标
+type HTTPTargetConfig struct {
+	URL           string
+	SigningKey    string // 用于 HMAC-SHA256 签名，置空则不签名
+	SigningHeader string // 默认 X-Subscheck-Signature
+}
+
+// TelegramTargetConfig 描述 Telegram Bot 回调目标
+type TelegramTargetConfig struct {
+	BotToken string
+	ChatID   string
+}
+
+// DiscordTargetConfig 描述 Discord Webhook 回调目标
+type DiscordTargetConfig struct {
+	WebhookURL string
+}
@@ -0,0 +1,105 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"subscheck-singbox/config"
+)
+
+// telegramDispatcher 通过 Telegram Bot API 发送检测结果摘要
+type telegramDispatcher struct {
+	botToken string
+	chatID   string
+	client   *http.Client
+}
+
+func newTelegramDispatcher(cfg config.TelegramTargetConfig) *telegramDispatcher {
+	return &telegramDispatcher{
+		botToken: cfg.BotToken,
+		chatID:   cfg.ChatID,
+		client:   &http.Client{},
+	}
+}
+
+func (t *telegramDispatcher) Name() string {
+	return fmt.Sprintf("telegram(%s)", t.chatID)
+}
+
+func (t *telegramDispatcher) Dispatch(ctx context.Context, payload Payload) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.botToken)
+	body, err := json.Marshal(map[string]string{
+		"chat_id": t.chatID,
+		"text":    summarize(payload),
+	})
+	if err != nil {
+		return fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("构造请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Telegram API 返回非成功状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// discordDispatcher 通过 Discord Webhook 发送检测结果摘要
+type discordDispatcher struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func newDiscordDispatcher(cfg config.DiscordTargetConfig) *discordDispatcher {
+	return &discordDispatcher{
+		webhookURL: cfg.WebhookURL,
+		client:     &http.Client{},
+	}
+}
+
+func (d *discordDispatcher) Name() string {
+	return "discord"
+}
+
+func (d *discordDispatcher) Dispatch(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(map[string]string{"content": summarize(payload)})
+	if err != nil {
+		return fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("构造请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Discord Webhook 返回非成功状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// summarize 生成一条适合聊天消息展示的检测结果摘要
+func summarize(payload Payload) string {
+	return fmt.Sprintf("subscheck 检测完成: %d/%d 个节点可用，耗时 %dms (run_id=%s)",
+		payload.SuccessCount, payload.TotalCount, payload.ElapsedMs, payload.RunID)
+}
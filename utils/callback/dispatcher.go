@@ -0,0 +1,67 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"subscheck-singbox/config"
+)
+
+// Dispatcher 是单个回调目标的分发实现
+type Dispatcher interface {
+	// Name 返回目标名称，用于日志标识
+	Name() string
+	// Dispatch 将 payload 发送给该目标，出错时返回 error
+	Dispatch(ctx context.Context, payload Payload) error
+}
+
+// BuildDispatchers 根据配置独立构造各回调目标对应的分发器。
+// 单个目标配置有误不应影响其它目标，因此构建失败的目标只会被记录并跳过，
+// 返回的两个切片一一对应，仅包含构建成功的目标
+func BuildDispatchers(targets []config.CallbackTarget) ([]Dispatcher, []config.CallbackTarget) {
+	dispatchers := make([]Dispatcher, 0, len(targets))
+	built := make([]config.CallbackTarget, 0, len(targets))
+	for _, target := range targets {
+		d, err := buildDispatcher(target)
+		if err != nil {
+			slog.Error(fmt.Sprintf("构建回调目标 %q 失败，已跳过: %v", target.Type, err))
+			continue
+		}
+		dispatchers = append(dispatchers, d)
+		built = append(built, target)
+	}
+	return dispatchers, built
+}
+
+func buildDispatcher(target config.CallbackTarget) (Dispatcher, error) {
+	switch target.Type {
+	case "exec":
+		if target.Exec == nil {
+			return nil, fmt.Errorf("exec 回调目标缺少配置")
+		}
+		return newExecDispatcher(*target.Exec), nil
+	case "http":
+		if target.HTTP == nil {
+			return nil, fmt.Errorf("http 回调目标缺少配置")
+		}
+		return newHTTPDispatcher(*target.HTTP), nil
+	case "telegram":
+		if target.Telegram == nil {
+			return nil, fmt.Errorf("telegram 回调目标缺少配置")
+		}
+		return newTelegramDispatcher(*target.Telegram), nil
+	case "discord":
+		if target.Discord == nil {
+			return nil, fmt.Errorf("discord 回调目标缺少配置")
+		}
+		return newDiscordDispatcher(*target.Discord), nil
+	case "selfupdate":
+		if target.SelfUpdate == nil {
+			return nil, fmt.Errorf("selfupdate 回调目标缺少配置")
+		}
+		return newSelfUpdateDispatcher(*target.SelfUpdate), nil
+	default:
+		return nil, fmt.Errorf("未知的回调目标类型: %s", target.Type)
+	}
+}
@@ -0,0 +1,157 @@
+package callback
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"subscheck-singbox/config"
+	"subscheck-singbox/utils"
+)
+
+// subscheckEventPrefix 标记回调脚本输出中携带结构化事件的行，
+// 形如 "::subscheck::key=value"
+const subscheckEventPrefix = "::subscheck::"
+
+// execDispatcher 通过 utils.ResolveInterpreter 执行本地脚本或可执行文件，
+// 并在沙箱中运行、逐行采集输出
+type execDispatcher struct {
+	scriptPath string
+	sandbox    *config.SandboxConfig
+}
+
+func newExecDispatcher(cfg config.ExecTargetConfig) *execDispatcher {
+	return &execDispatcher{scriptPath: cfg.ScriptPath, sandbox: cfg.Sandbox}
+}
+
+func (e *execDispatcher) Name() string {
+	return fmt.Sprintf("exec(%s)", e.scriptPath)
+}
+
+func (e *execDispatcher) Dispatch(ctx context.Context, payload Payload) error {
+	if _, err := os.Stat(e.scriptPath); os.IsNotExist(err) {
+		return fmt.Errorf("回调脚本不存在: %s", e.scriptPath)
+	}
+
+	cmd, err := utils.ResolveInterpreter(ctx, e.scriptPath)
+	if err != nil {
+		return fmt.Errorf("解析回调脚本解释器失败: %w", err)
+	}
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("SUCCESS_COUNT=%d", payload.SuccessCount),
+		fmt.Sprintf("TOTAL_COUNT=%d", payload.TotalCount),
+		fmt.Sprintf("RUN_ID=%s", payload.RunID),
+	)
+
+	handle, err := prepareSandbox(cmd, e.sandbox)
+	if err != nil {
+		return fmt.Errorf("准备沙箱失败: %w", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("创建 stdout 管道失败: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("创建 stderr 管道失败: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("启动回调脚本失败: %w", err)
+	}
+
+	if handle != nil {
+		if err := handle.afterStart(cmd.Process.Pid); err != nil {
+			handle.kill(cmd)
+			return fmt.Errorf("应用沙箱资源限制失败: %w", err)
+		}
+	}
+
+	events := &eventSink{values: make(map[string]string)}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go streamOutput(&wg, e.Name(), "stdout", stdout, events)
+	go streamOutput(&wg, e.Name(), "stderr", stderr, events)
+
+	done := make(chan error, 1)
+	go func() {
+		wg.Wait()
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return resolveExitError(events, err)
+	case <-ctx.Done():
+		handle.kill(cmd)
+		<-done
+		return ctx.Err()
+	}
+}
+
+// eventSink 并发安全地收集 stdout/stderr 两路输出中的 ::subscheck:: 事件
+type eventSink struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *eventSink) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+func (s *eventSink) get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// streamOutput 逐行读取子进程输出，通过 slog 打点，并收集 ::subscheck:: 结构化事件
+func streamOutput(wg *sync.WaitGroup, callbackName, stream string, r io.Reader, events *eventSink) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if key, value, ok := parseSubscheckEvent(line); ok {
+			events.set(key, value)
+			continue
+		}
+		slog.Info(line, slog.String("callback", callbackName), slog.String("stream", stream))
+	}
+}
+
+// parseSubscheckEvent 解析形如 "::subscheck::key=value" 的结构化事件行
+func parseSubscheckEvent(line string) (key, value string, ok bool) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(line), subscheckEventPrefix)
+	if !found {
+		return "", "", false
+	}
+	k, v, found := strings.Cut(rest, "=")
+	if !found {
+		return "", "", false
+	}
+	return k, v, true
+}
+
+// resolveExitError 让脚本通过 ::subscheck::exit_status=<code> 事件覆盖退出状态判定
+func resolveExitError(events *eventSink, waitErr error) error {
+	if status, ok := events.get("exit_status"); ok {
+		if status == "0" {
+			return nil
+		}
+		return fmt.Errorf("回调脚本报告失败状态: %s", status)
+	}
+	if waitErr != nil {
+		return fmt.Errorf("执行回调脚本失败: %w", waitErr)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package callback
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSubscheckEvent(t *testing.T) {
+	cases := []struct {
+		line      string
+		wantKey   string
+		wantValue string
+		wantOK    bool
+	}{
+		{"::subscheck::exit_status=0", "exit_status", "0", true},
+		{"  ::subscheck::metric=42  ", "metric", "42", true},
+		{"::subscheck::note=a=b", "note", "a=b", true},
+		{"regular log line", "", "", false},
+		{"::subscheck::nokeyvalue", "", "", false},
+	}
+
+	for _, tc := range cases {
+		key, value, ok := parseSubscheckEvent(tc.line)
+		if ok != tc.wantOK {
+			t.Fatalf("parseSubscheckEvent(%q) ok = %v, want %v", tc.line, ok, tc.wantOK)
+		}
+		if !ok {
+			continue
+		}
+		if key != tc.wantKey || value != tc.wantValue {
+			t.Fatalf("parseSubscheckEvent(%q) = (%q, %q), want (%q, %q)", tc.line, key, value, tc.wantKey, tc.wantValue)
+		}
+	}
+}
+
+func TestResolveExitError(t *testing.T) {
+	t.Run("exit_status overrides success", func(t *testing.T) {
+		events := &eventSink{values: map[string]string{"exit_status": "0"}}
+		if err := resolveExitError(events, errors.New("wait failed")); err != nil {
+			t.Fatalf("resolveExitError() = %v, want nil", err)
+		}
+	})
+
+	t.Run("exit_status overrides failure", func(t *testing.T) {
+		events := &eventSink{values: map[string]string{"exit_status": "1"}}
+		if err := resolveExitError(events, nil); err == nil {
+			t.Fatalf("resolveExitError() = nil, want error")
+		}
+	})
+
+	t.Run("falls back to wait error", func(t *testing.T) {
+		events := &eventSink{values: map[string]string{}}
+		waitErr := errors.New("boom")
+		if err := resolveExitError(events, waitErr); err == nil {
+			t.Fatalf("resolveExitError() = nil, want error")
+		}
+	})
+
+	t.Run("no event and no error is success", func(t *testing.T) {
+		events := &eventSink{values: map[string]string{}}
+		if err := resolveExitError(events, nil); err != nil {
+			t.Fatalf("resolveExitError() = %v, want nil", err)
+		}
+	})
+}
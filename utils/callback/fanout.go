@@ -0,0 +1,92 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"subscheck-singbox/config"
+)
+
+const (
+	defaultTargetTimeout = 10 * time.Second
+	defaultMaxRetries    = 2
+	defaultRetryBaseWait = 500 * time.Millisecond
+)
+
+// Dispatch 并发地将 payload 分发给所有目标，单个目标失败不会影响其它目标。
+//
+// selfupdate 目标会在其余目标全部结束后才执行：在 Unix 上它最终以
+// syscall.Exec 替换整个进程镜像，如果和其它目标的 goroutine 并发运行，
+// 会把尚未完成的 http/telegram/discord/exec 分发一并杀死
+func Dispatch(ctx context.Context, targets []config.CallbackTarget, payload Payload) {
+	var normal, deferredUpdates []config.CallbackTarget
+	for _, target := range targets {
+		if target.Type == "selfupdate" {
+			deferredUpdates = append(deferredUpdates, target)
+			continue
+		}
+		normal = append(normal, target)
+	}
+
+	dispatchAll(ctx, normal, payload)
+	dispatchAll(ctx, deferredUpdates, payload)
+}
+
+// dispatchAll 构造 targets 对应的分发器并并发执行，等待全部完成后返回
+func dispatchAll(ctx context.Context, targets []config.CallbackTarget, payload Payload) {
+	if len(targets) == 0 {
+		return
+	}
+
+	dispatchers, built := BuildDispatchers(targets)
+	if len(dispatchers) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i, d := range dispatchers {
+		wg.Add(1)
+		go func(d Dispatcher, target config.CallbackTarget) {
+			defer wg.Done()
+			dispatchWithRetry(ctx, d, target, payload)
+		}(d, built[i])
+	}
+	wg.Wait()
+}
+
+// dispatchWithRetry 在超时和重试次数内反复尝试一次分发，失败时按指数退避等待
+func dispatchWithRetry(ctx context.Context, d Dispatcher, target config.CallbackTarget, payload Payload) {
+	timeout := target.Timeout
+	if timeout <= 0 {
+		timeout = defaultTargetTimeout
+	}
+	maxRetries := target.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := defaultRetryBaseWait * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		lastErr = d.Dispatch(attemptCtx, payload)
+		cancel()
+		if lastErr == nil {
+			slog.Info(fmt.Sprintf("回调目标 %s 执行成功", d.Name()))
+			return
+		}
+		slog.Warn(fmt.Sprintf("回调目标 %s 第 %d 次尝试失败: %v", d.Name(), attempt+1, lastErr))
+	}
+	slog.Error(fmt.Sprintf("回调目标 %s 重试 %d 次后仍然失败: %v", d.Name(), maxRetries, lastErr))
+}
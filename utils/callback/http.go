@@ -0,0 +1,75 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"subscheck-singbox/config"
+)
+
+const defaultSigningHeader = "X-Subscheck-Signature"
+
+// httpDispatcher 以 JSON POST 的形式将 payload 发送到一个 Webhook URL
+type httpDispatcher struct {
+	url           string
+	signingKey    string
+	signingHeader string
+	client        *http.Client
+}
+
+func newHTTPDispatcher(cfg config.HTTPTargetConfig) *httpDispatcher {
+	header := cfg.SigningHeader
+	if header == "" {
+		header = defaultSigningHeader
+	}
+	return &httpDispatcher{
+		url:           cfg.URL,
+		signingKey:    cfg.SigningKey,
+		signingHeader: header,
+		client:        &http.Client{},
+	}
+}
+
+func (h *httpDispatcher) Name() string {
+	return fmt.Sprintf("http(%s)", h.url)
+}
+
+func (h *httpDispatcher) Dispatch(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化 payload 失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("构造请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.signingKey != "" {
+		req.Header.Set(h.signingHeader, signBody(h.signingKey, body))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("请求返回非成功状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signBody 使用 HMAC-SHA256 对请求体签名，返回十六进制编码的摘要
+func signBody(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,19 @@
+package callback
+
+// NodeResult 记录单个节点的检测结果，用于随 Payload 一起分发
+type NodeResult struct {
+	Name      string `json:"name"`
+	Protocol  string `json:"protocol"`
+	LatencyMs int64  `json:"latency_ms"`
+	Success   bool   `json:"success"`
+}
+
+// Payload 是一次检测完成后分发给各回调目标的结构化数据
+type Payload struct {
+	RunID           string       `json:"run_id"`
+	SubscriptionURL string       `json:"subscription_url"`
+	SuccessCount    int          `json:"success_count"`
+	TotalCount      int          `json:"total_count"`
+	ElapsedMs       int64        `json:"elapsed_ms"`
+	Nodes           []NodeResult `json:"nodes"`
+}
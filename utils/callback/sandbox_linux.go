@@ -0,0 +1,65 @@
+//go:build linux
+
+package callback
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+
+	"subscheck-singbox/config"
+)
+
+// sandboxHandle 在 Linux 上只需要记住沙箱配置，用于子进程启动后设置 rlimit；
+// 超时时按子进程 pid 杀死整个进程组
+type sandboxHandle struct {
+	cfg *config.SandboxConfig
+}
+
+// prepareSandbox 在子进程启动前配置独立进程组与可选的降权凭据，
+// 以便整组一起被杀死、且能以非特权用户运行回调脚本
+func prepareSandbox(cmd *exec.Cmd, cfg *config.SandboxConfig) (*sandboxHandle, error) {
+	attr := &syscall.SysProcAttr{Setpgid: true}
+	if cfg != nil && cfg.RunAsUID != nil && cfg.RunAsGID != nil {
+		attr.Credential = &syscall.Credential{Uid: *cfg.RunAsUID, Gid: *cfg.RunAsGID}
+	}
+	cmd.SysProcAttr = attr
+	return &sandboxHandle{cfg: cfg}, nil
+}
+
+// afterStart 通过 prlimit(2) 为已启动的子进程设置 CPU/内存/文件大小上限
+func (h *sandboxHandle) afterStart(pid int) error {
+	if h == nil || h.cfg == nil {
+		return nil
+	}
+	cfg := h.cfg
+	if cfg.MaxCPUSeconds > 0 {
+		if err := setRlimit(pid, syscall.RLIMIT_CPU, cfg.MaxCPUSeconds); err != nil {
+			return fmt.Errorf("设置 CPU 时间限制失败: %w", err)
+		}
+	}
+	if cfg.MaxMemoryBytes > 0 {
+		if err := setRlimit(pid, syscall.RLIMIT_AS, cfg.MaxMemoryBytes); err != nil {
+			return fmt.Errorf("设置内存限制失败: %w", err)
+		}
+	}
+	if cfg.MaxFileSizeBytes > 0 {
+		if err := setRlimit(pid, syscall.RLIMIT_FSIZE, cfg.MaxFileSizeBytes); err != nil {
+			return fmt.Errorf("设置文件大小限制失败: %w", err)
+		}
+	}
+	return nil
+}
+
+func setRlimit(pid int, resource int, limit uint64) error {
+	rlimit := syscall.Rlimit{Cur: limit, Max: limit}
+	return syscall.Prlimit(pid, resource, &rlimit, nil)
+}
+
+// kill 杀死回调子进程所在的整个进程组，确保超时时不留下孤儿进程
+func (h *sandboxHandle) kill(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,26 @@
+//go:build !linux && !windows
+
+package callback
+
+import (
+	"os/exec"
+
+	"subscheck-singbox/config"
+)
+
+// sandboxHandle 在未实现沙箱的平台上是一个空操作占位符
+type sandboxHandle struct{}
+
+func prepareSandbox(cmd *exec.Cmd, cfg *config.SandboxConfig) (*sandboxHandle, error) {
+	return nil, nil
+}
+
+func (h *sandboxHandle) afterStart(pid int) error {
+	return nil
+}
+
+func (h *sandboxHandle) kill(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		cmd.Process.Kill()
+	}
+}
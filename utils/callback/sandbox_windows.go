@@ -0,0 +1,122 @@
+//go:build windows
+
+package callback
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"subscheck-singbox/config"
+)
+
+var (
+	modkernel32                  = syscall.NewLazyDLL("kernel32.dll")
+	procCreateJobObjectW         = modkernel32.NewProc("CreateJobObjectW")
+	procSetInformationJobObject  = modkernel32.NewProc("SetInformationJobObject")
+	procAssignProcessToJobObject = modkernel32.NewProc("AssignProcessToJobObject")
+	procTerminateJobObject       = modkernel32.NewProc("TerminateJobObject")
+)
+
+const (
+	jobObjectExtendedLimitInformation = 9
+	limitKillOnJobClose               = 0x00002000
+	limitProcessMemory                = 0x00000100
+	limitProcessTime                  = 0x00000002
+)
+
+// jobObjectExtendedLimitInfo 对应 Win32 的 JOBOBJECT_EXTENDED_LIMIT_INFORMATION，
+// 这里只保留本包用到的字段，其余保持零值
+type jobObjectExtendedLimitInfo struct {
+	BasicLimitInformation struct {
+		PerProcessUserTimeLimit int64
+		PerJobUserTimeLimit     int64
+		LimitFlags              uint32
+		MinimumWorkingSetSize   uintptr
+		MaximumWorkingSetSize   uintptr
+		ActiveProcessLimit      uint32
+		Affinity                uintptr
+		PriorityClass           uint32
+		SchedulingClass         uint32
+	}
+	IoInfo                [48]byte
+	ProcessMemoryLimit    uintptr
+	JobMemoryLimit        uintptr
+	PeakProcessMemoryUsed uintptr
+	PeakJobMemoryUsed     uintptr
+}
+
+// sandboxHandle 在 Windows 上持有一个 Job Object 句柄，子进程启动后被分配进去，
+// 超时或异常时终止整个 job 即可连带结束其下所有进程
+type sandboxHandle struct {
+	handle syscall.Handle
+}
+
+// prepareSandbox 创建一个 Job Object，按配置限制其内存/CPU 时间，
+// 并设置 kill-on-job-close 以避免父进程退出后留下孤儿进程
+func prepareSandbox(cmd *exec.Cmd, cfg *config.SandboxConfig) (*sandboxHandle, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	handle, _, err := procCreateJobObjectW.Call(0, 0)
+	if handle == 0 {
+		return nil, fmt.Errorf("创建 Job Object 失败: %w", err)
+	}
+	h := &sandboxHandle{handle: syscall.Handle(handle)}
+
+	var info jobObjectExtendedLimitInfo
+	info.BasicLimitInformation.LimitFlags = limitKillOnJobClose
+	if cfg.MaxMemoryBytes > 0 {
+		info.BasicLimitInformation.LimitFlags |= limitProcessMemory
+		info.ProcessMemoryLimit = uintptr(cfg.MaxMemoryBytes)
+	}
+	if cfg.MaxCPUSeconds > 0 {
+		// 单位为 100 纳秒
+		info.BasicLimitInformation.LimitFlags |= limitProcessTime
+		info.BasicLimitInformation.PerProcessUserTimeLimit = int64(cfg.MaxCPUSeconds) * 10_000_000
+	}
+
+	ret, _, err := procSetInformationJobObject.Call(
+		uintptr(h.handle),
+		jobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)),
+		unsafe.Sizeof(info),
+	)
+	if ret == 0 {
+		syscall.CloseHandle(h.handle)
+		return nil, fmt.Errorf("配置 Job Object 限制失败: %w", err)
+	}
+	return h, nil
+}
+
+// afterStart 将已启动的子进程加入 job，使其受资源限制并在 job 关闭时一并终止
+func (h *sandboxHandle) afterStart(pid int) error {
+	if h == nil {
+		return nil
+	}
+	process, err := syscall.OpenProcess(syscall.PROCESS_ALL_ACCESS, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("打开进程句柄失败: %w", err)
+	}
+	defer syscall.CloseHandle(process)
+
+	ret, _, err := procAssignProcessToJobObject.Call(uintptr(h.handle), uintptr(process))
+	if ret == 0 {
+		return fmt.Errorf("将进程加入 Job Object 失败: %w", err)
+	}
+	return nil
+}
+
+// kill 终止整个 Job Object，从而结束子进程及其派生的所有进程
+func (h *sandboxHandle) kill(cmd *exec.Cmd) {
+	if h == nil {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+		return
+	}
+	procTerminateJobObject.Call(uintptr(h.handle), 1)
+	syscall.CloseHandle(h.handle)
+}
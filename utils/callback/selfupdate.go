@@ -0,0 +1,45 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+
+	"subscheck-singbox/config"
+	"subscheck-singbox/utils/selfupdate"
+)
+
+// selfUpdateDispatcher 把自更新作为一种回调目标动作：
+// 检测成功率低于配置阈值时触发一次更新检查并在有新版本时安装
+type selfUpdateDispatcher struct {
+	minSuccessRate float64
+}
+
+func newSelfUpdateDispatcher(cfg config.SelfUpdateTargetConfig) *selfUpdateDispatcher {
+	return &selfUpdateDispatcher{minSuccessRate: cfg.MinSuccessRate}
+}
+
+func (s *selfUpdateDispatcher) Name() string {
+	return "selfupdate"
+}
+
+func (s *selfUpdateDispatcher) Dispatch(ctx context.Context, payload Payload) error {
+	if s.minSuccessRate > 0 && payload.TotalCount > 0 {
+		rate := float64(payload.SuccessCount) / float64(payload.TotalCount)
+		if rate >= s.minSuccessRate {
+			return nil
+		}
+	}
+
+	cfg := config.GlobalConfig.SelfUpdate
+	release, newer, err := selfupdate.Check(ctx, cfg.ReleasesURL, cfg.CurrentVersion)
+	if err != nil {
+		return fmt.Errorf("检查更新失败: %w", err)
+	}
+	if !newer {
+		return nil
+	}
+	if err := selfupdate.Apply(ctx, release); err != nil {
+		return fmt.Errorf("安装更新失败: %w", err)
+	}
+	return nil
+}
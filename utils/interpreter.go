@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// interpretersByExt 列出 Windows 上按扩展名识别的脚本类型及其解释器候选列表，
+// 按优先级排列，第一个在 PATH 中能找到的即被使用
+var interpretersByExt = map[string][]string{
+	".sh":  {"bash", "sh"},
+	".py":  {"python", "python3"},
+	".js":  {"node"},
+	".ps1": {"pwsh", "powershell"},
+}
+
+// windowsExecSuffixes 是 Windows 下可直接执行的扩展名，按顺序尝试追加
+var windowsExecSuffixes = []string{".exe", ".cmd", ".bat"}
+
+// ResolveInterpreter 根据脚本路径构造一个可以正确执行它的 *exec.Cmd。
+//
+// 解析顺序：
+//  1. 非 Windows 平台上文件已设置执行位时，直接执行
+//  2. 否则读取 shebang 行（#!...）选择解释器，这对 NTFS/exFAT 等不保留
+//     执行位的文件系统尤为重要（即便是在 WSL/Linux 下挂载的情况）
+//  3. 在 Windows 上，没有 shebang 时按扩展名猜测解释器（.sh/.py/.js/.ps1）
+//  4. 以上都不适用时，直接执行该文件
+//
+// 解释器本身通过 exec.LookPath 定位，Windows 上会额外尝试 .exe/.cmd/.bat 后缀
+func ResolveInterpreter(ctx context.Context, scriptPath string) (*exec.Cmd, error) {
+	absPath, err := filepath.Abs(scriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("获取脚本绝对路径失败: %w", err)
+	}
+
+	if runtime.GOOS != "windows" && isExecutable(absPath) {
+		return exec.CommandContext(ctx, absPath), nil
+	}
+
+	if interpreter, args, ok := shebangInterpreter(absPath); ok {
+		bin, err := lookPath(interpreter)
+		if err != nil {
+			return nil, fmt.Errorf("定位解释器 %s 失败: %w", interpreter, err)
+		}
+		return exec.CommandContext(ctx, bin, append(args, absPath)...), nil
+	}
+
+	if runtime.GOOS == "windows" {
+		if candidates, ok := interpretersByExt[strings.ToLower(filepath.Ext(absPath))]; ok {
+			for _, name := range candidates {
+				if bin, err := lookPath(name); err == nil {
+					return exec.CommandContext(ctx, bin, absPath), nil
+				}
+			}
+			return nil, fmt.Errorf("未找到 %s 的可用解释器: %v", absPath, candidates)
+		}
+	}
+
+	return exec.CommandContext(ctx, absPath), nil
+}
+
+// isExecutable 判断文件是否设置了任意执行位
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
+
+// shebangInterpreter 解析文件首行的 shebang，返回解释器名及附加参数
+func shebangInterpreter(path string) (interpreter string, args []string, ok bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return "", nil, false
+	}
+	line := scanner.Text()
+	if !strings.HasPrefix(line, "#!") {
+		return "", nil, false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+
+	// 处理 "#!/usr/bin/env bash" 这类写法，
+	// 同时跳过 env 自身的选项（如 -S、-i、--ignore-environment、--）
+	// 找到真正的解释器名称，例如 "#!/usr/bin/env -S node --experimental"
+	if filepath.Base(fields[0]) == "env" && len(fields) > 1 {
+		rest := fields[1:]
+		for len(rest) > 0 && strings.HasPrefix(rest[0], "-") {
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			return "", nil, false
+		}
+		return filepath.Base(rest[0]), rest[1:], true
+	}
+	return filepath.Base(fields[0]), fields[1:], true
+}
+
+// lookPath 在 PATH 中定位可执行文件，Windows 上额外尝试常见的可执行后缀
+func lookPath(name string) (string, error) {
+	if bin, err := exec.LookPath(name); err == nil {
+		return bin, nil
+	}
+	if runtime.GOOS == "windows" {
+		for _, suffix := range windowsExecSuffixes {
+			if bin, err := exec.LookPath(name + suffix); err == nil {
+				return bin, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("在 PATH 中未找到 %s", name)
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestShebangInterpreter(t *testing.T) {
+	cases := []struct {
+		name        string
+		content     string
+		wantOK      bool
+		wantBin     string
+		wantArgsLen int
+	}{
+		{name: "plain shebang", content: "#!/bin/bash\necho hi\n", wantOK: true, wantBin: "bash"},
+		{name: "env wrapper", content: "#!/usr/bin/env python3\nprint('hi')\n", wantOK: true, wantBin: "python3"},
+		{name: "env wrapper with -S flag", content: "#!/usr/bin/env -S node --experimental\n", wantOK: true, wantBin: "node", wantArgsLen: 1},
+		{name: "no shebang", content: "echo hi\n", wantOK: false},
+		{name: "empty file", content: "", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "script")
+			if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+				t.Fatalf("写入测试脚本失败: %v", err)
+			}
+
+			bin, args, ok := shebangInterpreter(path)
+			if ok != tc.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if bin != tc.wantBin {
+				t.Fatalf("bin = %q, want %q", bin, tc.wantBin)
+			}
+			if tc.wantArgsLen != 0 && len(args) != tc.wantArgsLen {
+				t.Fatalf("len(args) = %d, want %d", len(args), tc.wantArgsLen)
+			}
+		})
+	}
+}
+
+func TestIsExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("执行位语义在 windows 上不适用")
+	}
+
+	path := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(path, []byte("echo hi\n"), 0o644); err != nil {
+		t.Fatalf("写入测试脚本失败: %v", err)
+	}
+	if isExecutable(path) {
+		t.Fatalf("未设置执行位的文件不应被判定为可执行")
+	}
+
+	if err := os.Chmod(path, 0o755); err != nil {
+		t.Fatalf("设置执行位失败: %v", err)
+	}
+	if !isExecutable(path) {
+		t.Fatalf("设置了执行位的文件应被判定为可执行")
+	}
+}
+
+func TestInterpretersByExtCoversCommonScripts(t *testing.T) {
+	for _, ext := range []string{".sh", ".py", ".js", ".ps1"} {
+		if _, ok := interpretersByExt[ext]; !ok {
+			t.Fatalf("interpretersByExt 缺少 %s 的候选解释器", ext)
+		}
+	}
+}
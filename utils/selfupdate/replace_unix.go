@@ -0,0 +1,46 @@
+//go:build !windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// replaceRunningBinary 将当前可执行文件替换为 data 的内容，
+// 旧文件保留为 "<exe>.bak" 以便失败时回滚，随后通过 syscall.Exec 重新执行自身
+func replaceRunningBinary(data []byte) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("获取当前可执行文件路径失败: %w", err)
+	}
+	exePath, err = filepath.EvalSymlinks(exePath)
+	if err != nil {
+		return fmt.Errorf("解析可执行文件真实路径失败: %w", err)
+	}
+
+	tmpPath := exePath + ".new"
+	if err := os.WriteFile(tmpPath, data, 0o755); err != nil {
+		return fmt.Errorf("写入新版本失败: %w", err)
+	}
+
+	bakPath := exePath + ".bak"
+	if err := os.Rename(exePath, bakPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("备份旧版本失败: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		// 回滚：恢复旧版本，让调用方可以重试
+		os.Rename(bakPath, exePath)
+		return fmt.Errorf("替换可执行文件失败: %w", err)
+	}
+
+	if err := os.Chmod(exePath, 0o755); err != nil {
+		return fmt.Errorf("设置可执行权限失败: %w", err)
+	}
+
+	return syscall.Exec(exePath, os.Args, os.Environ())
+}
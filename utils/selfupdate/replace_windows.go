@@ -0,0 +1,47 @@
+//go:build windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// replaceRunningBinary 在 Windows 上无法覆盖正在运行的自身可执行文件，
+// 因此将新版本写入临时文件，结束时把旧文件重命名为 "<exe>.bak"、
+// 新文件就位后再拉起一个新进程继续运行，自身随后退出
+func replaceRunningBinary(data []byte) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("获取当前可执行文件路径失败: %w", err)
+	}
+
+	tmpPath := exePath + ".new"
+	if err := os.WriteFile(tmpPath, data, 0o755); err != nil {
+		return fmt.Errorf("写入新版本失败: %w", err)
+	}
+
+	bakPath := exePath + ".bak"
+	os.Remove(bakPath)
+	if err := os.Rename(exePath, bakPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("备份旧版本失败: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		os.Rename(bakPath, exePath)
+		return fmt.Errorf("替换可执行文件失败: %w", err)
+	}
+
+	cmd := exec.Command(exePath, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("启动新版本进程失败: %w", err)
+	}
+
+	os.Exit(0)
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package selfupdate 实现 subscheck-singbox 二进制的自更新能力：
+// 检查 GitHub Releases 上的最新版本、下载匹配当前平台的资源、校验完整性，
+// 并原地替换正在运行的可执行文件
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Asset 是一个 GitHub Release 附件
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Release 是一次 GitHub Release 查询结果中与自更新相关的部分
+type Release struct {
+	TagName string  `json:"tag_name"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Version 去掉 tag 前缀的 "v"，返回裸的语义化版本号
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// FetchLatest 查询 releasesURL（GitHub Releases API 的 "latest" 端点）返回最新 Release
+func FetchLatest(ctx context.Context, releasesURL string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构造请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求发布信息失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求发布信息返回非成功状态码: %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("解析发布信息失败: %w", err)
+	}
+	return &release, nil
+}
+
+// IsNewer 判断 latest 相对于 current 是否是更新的语义化版本
+func IsNewer(current, latest string) bool {
+	return compareVersions(current, latest) < 0
+}
+
+// compareVersions 比较两个形如 "1.2.3" 的版本号，返回 -1/0/1
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// SelectAsset 在 release 中查找匹配当前操作系统/架构的资源，
+// 约定命名形如 subscheck-singbox_<GOOS>_<GOARCH>[.exe]
+func SelectAsset(release *Release, goos, goarch string) (*Asset, error) {
+	suffix := ""
+	if goos == "windows" {
+		suffix = ".exe"
+	}
+	want := fmt.Sprintf("subscheck-singbox_%s_%s%s", goos, goarch, suffix)
+	for _, asset := range release.Assets {
+		if asset.Name == want {
+			return &asset, nil
+		}
+	}
+	return nil, fmt.Errorf("未找到匹配 %s 的发布资源", want)
+}
+
+// assetChecksum 在资源列表中查找名为 checksums.txt 的附属文件，返回其中与 assetName 对应的 SHA256
+func assetChecksum(ctx context.Context, release *Release, assetName string) (string, error) {
+	for _, asset := range release.Assets {
+		if asset.Name != "checksums.txt" {
+			continue
+		}
+		data, err := Download(ctx, asset.BrowserDownloadURL)
+		if err != nil {
+			return "", fmt.Errorf("下载 checksums.txt 失败: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[1] == assetName {
+				return fields[0], nil
+			}
+		}
+		return "", fmt.Errorf("checksums.txt 中未找到 %s 的校验和", assetName)
+	}
+	return "", fmt.Errorf("发布中未包含 checksums.txt")
+}
+
+// Download 下载 url 指向的资源并返回其完整内容
+func Download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构造请求失败: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("下载失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("下载返回非成功状态码: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// VerifySHA256 校验 data 的 SHA256 摘要是否与 want（十六进制）一致
+func VerifySHA256(data []byte, want string) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, want) {
+		return fmt.Errorf("SHA256 校验失败: 期望 %s，实际 %s", want, got)
+	}
+	return nil
+}
+
+// Check 查询最新版本并与 currentVersion 比较，返回最新 Release 以及是否有更新
+func Check(ctx context.Context, releasesURL, currentVersion string) (*Release, bool, error) {
+	release, err := FetchLatest(ctx, releasesURL)
+	if err != nil {
+		return nil, false, err
+	}
+	return release, IsNewer(currentVersion, release.Version()), nil
+}
+
+// Apply 下载并校验 release 中匹配当前平台的资源，然后原地替换正在运行的可执行文件。
+// 替换前会将当前二进制备份为 "<exe>.bak"，失败时调用方可以据此回滚
+func Apply(ctx context.Context, release *Release) error {
+	asset, err := SelectAsset(release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	data, err := Download(ctx, asset.BrowserDownloadURL)
+	if err != nil {
+		return fmt.Errorf("下载新版本失败: %w", err)
+	}
+
+	checksum, err := assetChecksum(ctx, release, asset.Name)
+	if err != nil {
+		return fmt.Errorf("获取校验和失败，拒绝安装未经验证的版本: %w", err)
+	}
+	if err := VerifySHA256(data, checksum); err != nil {
+		return err
+	}
+
+	return replaceRunningBinary(data)
+}
@@ -0,0 +1,66 @@
+package selfupdate
+
+import "testing"
+
+func TestIsNewer(t *testing.T) {
+	cases := []struct {
+		current, latest string
+		want             bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"1.2.3", "1.3.0", true},
+		{"1.2.3", "2.0.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.2.3", "1.2.2", false},
+		{"1.9.0", "1.10.0", true},
+		{"1.2", "1.2.1", true},
+	}
+
+	for _, tc := range cases {
+		if got := IsNewer(tc.current, tc.latest); got != tc.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tc.current, tc.latest, got, tc.want)
+		}
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	r := Release{TagName: "v1.4.2"}
+	if got := r.Version(); got != "1.4.2" {
+		t.Fatalf("Version() = %q, want %q", got, "1.4.2")
+	}
+
+	r = Release{TagName: "1.4.2"}
+	if got := r.Version(); got != "1.4.2" {
+		t.Fatalf("Version() = %q, want %q", got, "1.4.2")
+	}
+}
+
+func TestSelectAsset(t *testing.T) {
+	release := &Release{
+		Assets: []Asset{
+			{Name: "subscheck-singbox_linux_amd64"},
+			{Name: "subscheck-singbox_windows_amd64.exe"},
+			{Name: "checksums.txt"},
+		},
+	}
+
+	asset, err := SelectAsset(release, "linux", "amd64")
+	if err != nil {
+		t.Fatalf("SelectAsset 返回了错误: %v", err)
+	}
+	if asset.Name != "subscheck-singbox_linux_amd64" {
+		t.Fatalf("asset.Name = %q, want %q", asset.Name, "subscheck-singbox_linux_amd64")
+	}
+
+	asset, err = SelectAsset(release, "windows", "amd64")
+	if err != nil {
+		t.Fatalf("SelectAsset 返回了错误: %v", err)
+	}
+	if asset.Name != "subscheck-singbox_windows_amd64.exe" {
+		t.Fatalf("asset.Name = %q, want %q", asset.Name, "subscheck-singbox_windows_amd64.exe")
+	}
+
+	if _, err := SelectAsset(release, "darwin", "arm64"); err == nil {
+		t.Fatalf("SelectAsset 应在没有匹配资源时返回错误")
+	}
+}